@@ -0,0 +1,218 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// quasirandom wraps gsl quasi-random (low-discrepancy) sequence
+// generation routines
+package quasirandom
+
+// #cgo CFLAGS: -std=c99 -O2
+// #cgo pkg-config: gsl
+// #include <string.h>
+// #include <gsl/gsl_qrng.h>
+import "C"
+
+import (
+  "fmt"
+  "io"
+  "os"
+  "runtime"
+  "unsafe"
+)
+
+// QrngState stores the quasi-random number generator state. closer is a
+// separate heap-allocated object that the finalizer freeing the
+// underlying gsl_qrng is attached to; since QrngState is handed around
+// by value, every copy shares the same closer pointer, which keeps that
+// object -- and so the C generator it owns -- reachable for as long as
+// any copy of the QrngState is, instead of only the original returned
+// by Alloc.
+type QrngState struct {
+  state  *C.gsl_qrng
+  dim    uint
+  closer *qrngCloser
+}
+
+type qrngCloser struct {
+  ptr *C.gsl_qrng
+}
+
+// QrngType stores the type of qrng method used
+type QrngType struct {
+  rng *C.gsl_qrng_type
+}
+
+// list of defined quasi-random number generators. See gsl documentation
+// for more detailed info on each of these.
+var (
+  Niederreiter2 = QrngType{C.gsl_qrng_niederreiter_2}
+  Sobol         = QrngType{C.gsl_qrng_sobol}
+  Halton        = QrngType{C.gsl_qrng_halton}
+  ReverseHalton = QrngType{C.gsl_qrng_reversehalton}
+)
+
+// newQrngState wraps ptr in a QrngState, attaching a finalizer to a
+// dedicated closer object (see QrngState) so the underlying gsl_qrng is
+// freed once the last copy of the returned QrngState becomes
+// unreachable, rather than as soon as Alloc/Clone's local variable goes
+// out of scope.
+func newQrngState(ptr *C.gsl_qrng, dim uint) QrngState {
+  closer := &qrngCloser{ptr}
+  runtime.SetFinalizer(closer,
+    func(c *qrngCloser) { C.gsl_qrng_free(c.ptr) })
+  return QrngState{ptr, dim, closer}
+}
+
+// Alloc creates a new quasi-random number generator of the given type and
+// dimension dim, and returns it as a QrngState object.
+func Alloc(qrngType QrngType, dim uint) QrngState {
+  return newQrngState(C.gsl_qrng_alloc(qrngType.rng, C.uint(dim)), dim)
+}
+
+// Init reinitializes the quasi-random number generator q to its starting
+// point. Note that quasi-random sequences do not use a seed -- the entire
+// sequence is reproducible on subsequent runs of a program simply by
+// reinitializing the generator.
+func (q QrngState) Init() {
+  C.gsl_qrng_init(q.state)
+}
+
+// Get fills buf with the next point in the dim-dimensional sequence,
+// where len(buf) must match the dimension q was allocated with.
+func (q QrngState) Get(buf []float64) error {
+  if len(buf) != int(q.dim) {
+    return fmt.Errorf("quasirandom: buffer length %d does not match generator dimension %d",
+      len(buf), q.dim)
+  }
+
+  status := int(C.gsl_qrng_get(q.state, (*C.double)(&buf[0])))
+  if status != 0 {
+    return fmt.Errorf("quasirandom: failed to generate next point")
+  }
+  return nil
+}
+
+// Sequence returns n points of the dim-dimensional low-discrepancy
+// sequence produced by a generator of type t. It is a convenience
+// function for the common case of needing a fixed batch of points up
+// front rather than driving a QrngState by hand. It returns an error,
+// without returning partial results, if the underlying generator fails.
+func (t QrngType) Sequence(n, dim int) ([][]float64, error) {
+  q := Alloc(t, uint(dim))
+
+  points := make([][]float64, n)
+  for i := range points {
+    points[i] = make([]float64, dim)
+    if err := q.Get(points[i]); err != nil {
+      return nil, err
+    }
+  }
+  return points, nil
+}
+
+// Name returns the name of the quasi-random number generator or a qrng
+// type.
+func (q QrngState) Name() string {
+  return C.GoString(C.gsl_qrng_name(q.state))
+}
+
+func (t QrngType) Name() string {
+  return C.GoString(t.rng.name)
+}
+
+// String provides a printable string representation for a QrngState and
+// type.
+func (q QrngState) String() string {
+  return q.Name()
+}
+
+func (t QrngType) String() string {
+  return C.GoString(t.rng.name)
+}
+
+// Dim returns the dimension q was allocated with.
+func (q QrngState) Dim() uint {
+  return q.dim
+}
+
+// Size returns the size of the qrng state.
+func (q QrngState) Size() uint64 {
+  return uint64(C.gsl_qrng_size(q.state))
+}
+
+// Memcpy copies the quasi-random number generator src into the
+// pre-existing generator dest, making dest into an exact copy of src.
+// The two generators must be of the same type.
+func (q QrngState) Memcpy(dest QrngState) {
+  C.gsl_qrng_memcpy(dest.state, q.state)
+}
+
+// Clone returns a newly created generator which is an exact copy of q.
+func (q QrngState) Clone() QrngState {
+  return newQrngState(C.gsl_qrng_clone(q.state), q.dim)
+}
+
+// WriteState writes the quasi-random number state of q to w in binary
+// format, consistent with RngState.WriteState in the sibling random
+// package. Data is written in the native binary format and may not be
+// portable between different architectures.
+func (q QrngState) WriteState(w io.Writer) (int64, error) {
+  size := C.gsl_qrng_size(q.state)
+  buf := C.GoBytes(C.gsl_qrng_state(q.state), C.int(size))
+
+  n, err := w.Write(buf)
+  return int64(n), err
+}
+
+// ReadState reads the quasi-random number state into q from r in binary
+// format. q must be preinitialized with the correct generator type and
+// dimension since neither is saved with the state. The data is assumed
+// to have been written by WriteState on the same architecture.
+func (q QrngState) ReadState(r io.Reader) (int64, error) {
+  size := C.gsl_qrng_size(q.state)
+  buf := make([]byte, size)
+
+  n, err := io.ReadFull(r, buf)
+  if err != nil {
+    return int64(n), err
+  }
+
+  C.memcpy(C.gsl_qrng_state(q.state), unsafe.Pointer(&buf[0]), size)
+  return int64(n), nil
+}
+
+// Fwrite writes the quasi-random number state of q to the given file in
+// binary format, consistent with RngState.Fwrite in the sibling random
+// package. Data is written in the native binary format and may not be
+// portable between different architectures. Returns an error if there
+// was a problem writing.
+func (q QrngState) Fwrite(filename string) error {
+  f, err := os.Create(filename)
+  if err != nil {
+    return fmt.Errorf("Failed to write qrng state to file: %v", err)
+  }
+  defer f.Close()
+
+  if _, err := q.WriteState(f); err != nil {
+    return fmt.Errorf("Failed to write qrng state to file: %v", err)
+  }
+  return nil
+}
+
+// Fread reads the quasi-random number state into q from the given file
+// name in binary format. q must be preinitialized with the correct
+// generator type and dimension since neither is saved with the state.
+// The data is assumed to have been written in the native binary format
+// on the same architecture. Returns an error if reading fails.
+func (q QrngState) Fread(filename string) (QrngState, error) {
+  f, err := os.Open(filename)
+  if err != nil {
+    return q, fmt.Errorf("Failed to read qrng state from file: %v", err)
+  }
+  defer f.Close()
+
+  if _, err := q.ReadState(f); err != nil {
+    return q, fmt.Errorf("Failed to read qrng state from file: %v", err)
+  }
+  return q, nil
+}
@@ -0,0 +1,557 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// distributions wraps the gsl_ran_* random variate distributions built
+// on top of the RngState generators in random.go
+package random
+
+// #cgo CFLAGS: -std=c99 -O2
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_rng.h>
+// #include <gsl/gsl_randist.h>
+import "C"
+
+// Gaussian distribution
+
+// Gaussian returns a gaussian random variate with mean zero and standard
+// deviation sigma. The probability distribution for gaussian random
+// variates is p(x) dx = {1 \over \sqrt{2 \pi \sigma^2}} \exp(-x^2 /
+// 2\sigma^2) dx, for x in the range (-\infty, +\infty).
+func (s RngState) Gaussian(sigma float64) float64 {
+  return float64(C.gsl_ran_gaussian(s.state, C.double(sigma)))
+}
+
+// GaussianPdf computes the probability density p(x) at x for a gaussian
+// distribution with standard deviation sigma.
+func GaussianPdf(x, sigma float64) float64 {
+  return float64(C.gsl_ran_gaussian_pdf(C.double(x), C.double(sigma)))
+}
+
+// GaussianZiggurat computes a gaussian random variate using the alternative
+// Marsaglia-Tsang ziggurat algorithm, which is typically faster than
+// Gaussian.
+func (s RngState) GaussianZiggurat(sigma float64) float64 {
+  return float64(C.gsl_ran_gaussian_ziggurat(s.state, C.double(sigma)))
+}
+
+// GaussianRatioMethod computes a gaussian random variate using the
+// Kinderman-Monahan ratio method.
+func (s RngState) GaussianRatioMethod(sigma float64) float64 {
+  return float64(C.gsl_ran_gaussian_ratio_method(s.state, C.double(sigma)))
+}
+
+// Ugaussian is equivalent to Gaussian with sigma = 1, the unit gaussian
+// distribution.
+func (s RngState) Ugaussian() float64 {
+  return float64(C.gsl_ran_ugaussian(s.state))
+}
+
+// UgaussianPdf computes the probability density p(x) at x for a unit
+// gaussian distribution.
+func UgaussianPdf(x float64) float64 {
+  return float64(C.gsl_ran_ugaussian_pdf(C.double(x)))
+}
+
+// UgaussianRatioMethod is equivalent to GaussianRatioMethod with sigma = 1.
+func (s RngState) UgaussianRatioMethod() float64 {
+  return float64(C.gsl_ran_ugaussian_ratio_method(s.state))
+}
+
+// GaussianTail provides random variates from the upper tail of a gaussian
+// distribution with standard deviation sigma. The values returned are
+// larger than the lower limit a, which must itself be positive.
+func (s RngState) GaussianTail(a, sigma float64) float64 {
+  return float64(C.gsl_ran_gaussian_tail(s.state, C.double(a), C.double(sigma)))
+}
+
+// GaussianTailPdf computes the probability density p(x) at x for a gaussian
+// tail distribution with standard deviation sigma and lower limit a.
+func GaussianTailPdf(x, a, sigma float64) float64 {
+  return float64(C.gsl_ran_gaussian_tail_pdf(C.double(x), C.double(a), C.double(sigma)))
+}
+
+// BivariateGaussian generates a pair of correlated gaussian variates, with
+// mean zero, correlation coefficient rho and standard deviations sigmaX
+// and sigmaY in the x and y directions.
+func (s RngState) BivariateGaussian(sigmaX, sigmaY, rho float64) (float64, float64) {
+  var x, y C.double
+  C.gsl_ran_bivariate_gaussian(s.state, C.double(sigmaX), C.double(sigmaY),
+    C.double(rho), &x, &y)
+  return float64(x), float64(y)
+}
+
+// BivariateGaussianPdf computes the probability density p(x,y) at (x,y)
+// for a bivariate gaussian distribution with standard deviations sigmaX,
+// sigmaY and correlation coefficient rho.
+func BivariateGaussianPdf(x, y, sigmaX, sigmaY, rho float64) float64 {
+  return float64(C.gsl_ran_bivariate_gaussian_pdf(C.double(x), C.double(y),
+    C.double(sigmaX), C.double(sigmaY), C.double(rho)))
+}
+
+// Exponential distribution
+
+// Exponential returns a random variate from the exponential distribution
+// with mean mu. The distribution is p(x) dx = {1 \over \mu} \exp(-x/\mu)
+// dx, for x >= 0.
+func (s RngState) Exponential(mu float64) float64 {
+  return float64(C.gsl_ran_exponential(s.state, C.double(mu)))
+}
+
+// ExponentialPdf computes the probability density p(x) at x for an
+// exponential distribution with mean mu.
+func ExponentialPdf(x, mu float64) float64 {
+  return float64(C.gsl_ran_exponential_pdf(C.double(x), C.double(mu)))
+}
+
+// Laplace distribution
+
+// Laplace returns a random variate from the Laplace distribution with
+// width a. The distribution is p(x) dx = {1 \over 2 a} \exp(-|x/a|) dx,
+// for -\infty < x < \infty.
+func (s RngState) Laplace(a float64) float64 {
+  return float64(C.gsl_ran_laplace(s.state, C.double(a)))
+}
+
+// LaplacePdf computes the probability density p(x) at x for a Laplace
+// distribution with width a.
+func LaplacePdf(x, a float64) float64 {
+  return float64(C.gsl_ran_laplace_pdf(C.double(x), C.double(a)))
+}
+
+// Exponential power distribution
+
+// ExpPow returns a random variate from the exponential power distribution
+// with scale parameter a and exponent b.
+func (s RngState) ExpPow(a, b float64) float64 {
+  return float64(C.gsl_ran_exppow(s.state, C.double(a), C.double(b)))
+}
+
+// ExpPowPdf computes the probability density p(x) at x for an exponential
+// power distribution with scale parameter a and exponent b.
+func ExpPowPdf(x, a, b float64) float64 {
+  return float64(C.gsl_ran_exppow_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Cauchy distribution
+
+// Cauchy returns a random variate from the Cauchy (Lorentzian)
+// distribution with scale parameter a.
+func (s RngState) Cauchy(a float64) float64 {
+  return float64(C.gsl_ran_cauchy(s.state, C.double(a)))
+}
+
+// CauchyPdf computes the probability density p(x) at x for a Cauchy
+// distribution with scale parameter a.
+func CauchyPdf(x, a float64) float64 {
+  return float64(C.gsl_ran_cauchy_pdf(C.double(x), C.double(a)))
+}
+
+// Rayleigh distribution
+
+// Rayleigh returns a random variate from the Rayleigh distribution with
+// scale parameter sigma.
+func (s RngState) Rayleigh(sigma float64) float64 {
+  return float64(C.gsl_ran_rayleigh(s.state, C.double(sigma)))
+}
+
+// RayleighPdf computes the probability density p(x) at x for a Rayleigh
+// distribution with scale parameter sigma.
+func RayleighPdf(x, sigma float64) float64 {
+  return float64(C.gsl_ran_rayleigh_pdf(C.double(x), C.double(sigma)))
+}
+
+// RayleighTail returns a random variate from the tail of the Rayleigh
+// distribution with scale parameter sigma and lower limit a.
+func (s RngState) RayleighTail(a, sigma float64) float64 {
+  return float64(C.gsl_ran_rayleigh_tail(s.state, C.double(a), C.double(sigma)))
+}
+
+// RayleighTailPdf computes the probability density p(x) at x for a
+// Rayleigh tail distribution with scale parameter sigma and lower limit a.
+func RayleighTailPdf(x, a, sigma float64) float64 {
+  return float64(C.gsl_ran_rayleigh_tail_pdf(C.double(x), C.double(a), C.double(sigma)))
+}
+
+// Landau distribution
+
+// Landau returns a random variate from the Landau distribution.
+func (s RngState) Landau() float64 {
+  return float64(C.gsl_ran_landau(s.state))
+}
+
+// LandauPdf computes the probability density p(x) at x for the Landau
+// distribution.
+func LandauPdf(x float64) float64 {
+  return float64(C.gsl_ran_landau_pdf(C.double(x)))
+}
+
+// Levy distribution
+
+// Levy returns a random variate from the Levy symmetric stable
+// distribution with scale c and exponent alpha. The algorithm only works
+// for 0 < alpha <= 2.
+func (s RngState) Levy(c, alpha float64) float64 {
+  return float64(C.gsl_ran_levy(s.state, C.double(c), C.double(alpha)))
+}
+
+// Gamma distribution
+
+// Gamma returns a random variate from the gamma distribution with shape
+// parameter a and scale parameter b, using the Marsaglia-Tsang fast gamma
+// method.
+func (s RngState) Gamma(a, b float64) float64 {
+  return float64(C.gsl_ran_gamma(s.state, C.double(a), C.double(b)))
+}
+
+// GammaKnuth returns a random variate from the gamma distribution using
+// the algorithm from Knuth (vol 2). This is slower than Gamma but retained
+// for backwards compatibility.
+func (s RngState) GammaKnuth(a, b float64) float64 {
+  return float64(C.gsl_ran_gamma_knuth(s.state, C.double(a), C.double(b)))
+}
+
+// GammaPdf computes the probability density p(x) at x for a gamma
+// distribution with shape parameter a and scale parameter b.
+func GammaPdf(x, a, b float64) float64 {
+  return float64(C.gsl_ran_gamma_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Beta distribution
+
+// Beta returns a random variate from the beta distribution with shape
+// parameters a and b.
+func (s RngState) Beta(a, b float64) float64 {
+  return float64(C.gsl_ran_beta(s.state, C.double(a), C.double(b)))
+}
+
+// BetaPdf computes the probability density p(x) at x for a beta
+// distribution with shape parameters a and b.
+func BetaPdf(x, a, b float64) float64 {
+  return float64(C.gsl_ran_beta_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Chi-squared distribution
+
+// Chisq returns a random variate from the chi-squared distribution with
+// nu degrees of freedom.
+func (s RngState) Chisq(nu float64) float64 {
+  return float64(C.gsl_ran_chisq(s.state, C.double(nu)))
+}
+
+// ChisqPdf computes the probability density p(x) at x for a chi-squared
+// distribution with nu degrees of freedom.
+func ChisqPdf(x, nu float64) float64 {
+  return float64(C.gsl_ran_chisq_pdf(C.double(x), C.double(nu)))
+}
+
+// F-distribution
+
+// Fdist returns a random variate from the F-distribution with degrees of
+// freedom nu1 and nu2.
+func (s RngState) Fdist(nu1, nu2 float64) float64 {
+  return float64(C.gsl_ran_fdist(s.state, C.double(nu1), C.double(nu2)))
+}
+
+// FdistPdf computes the probability density p(x) at x for an F-distribution
+// with degrees of freedom nu1 and nu2.
+func FdistPdf(x, nu1, nu2 float64) float64 {
+  return float64(C.gsl_ran_fdist_pdf(C.double(x), C.double(nu1), C.double(nu2)))
+}
+
+// t-distribution
+
+// Tdist returns a random variate from the t-distribution with nu degrees
+// of freedom.
+func (s RngState) Tdist(nu float64) float64 {
+  return float64(C.gsl_ran_tdist(s.state, C.double(nu)))
+}
+
+// TdistPdf computes the probability density p(x) at x for a t-distribution
+// with nu degrees of freedom.
+func TdistPdf(x, nu float64) float64 {
+  return float64(C.gsl_ran_tdist_pdf(C.double(x), C.double(nu)))
+}
+
+// Logistic distribution
+
+// Logistic returns a random variate from the logistic distribution with
+// scale parameter a.
+func (s RngState) Logistic(a float64) float64 {
+  return float64(C.gsl_ran_logistic(s.state, C.double(a)))
+}
+
+// LogisticPdf computes the probability density p(x) at x for a logistic
+// distribution with scale parameter a.
+func LogisticPdf(x, a float64) float64 {
+  return float64(C.gsl_ran_logistic_pdf(C.double(x), C.double(a)))
+}
+
+// Pareto distribution
+
+// Pareto returns a random variate from the Pareto distribution with
+// exponent a and scale b. The distribution is p(x) dx = (a/b) / (x/b)^{a+1}
+// dx, for x >= b.
+func (s RngState) Pareto(a, b float64) float64 {
+  return float64(C.gsl_ran_pareto(s.state, C.double(a), C.double(b)))
+}
+
+// ParetoPdf computes the probability density p(x) at x for a Pareto
+// distribution with exponent a and scale b.
+func ParetoPdf(x, a, b float64) float64 {
+  return float64(C.gsl_ran_pareto_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Weibull distribution
+
+// Weibull returns a random variate from the Weibull distribution with
+// scale a and exponent b.
+func (s RngState) Weibull(a, b float64) float64 {
+  return float64(C.gsl_ran_weibull(s.state, C.double(a), C.double(b)))
+}
+
+// WeibullPdf computes the probability density p(x) at x for a Weibull
+// distribution with scale a and exponent b.
+func WeibullPdf(x, a, b float64) float64 {
+  return float64(C.gsl_ran_weibull_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Type-1 Gumbel distribution
+
+// Gumbel1 returns a random variate from the Type-1 Gumbel distribution
+// with parameters a and b.
+func (s RngState) Gumbel1(a, b float64) float64 {
+  return float64(C.gsl_ran_gumbel1(s.state, C.double(a), C.double(b)))
+}
+
+// Gumbel1Pdf computes the probability density p(x) at x for a Type-1
+// Gumbel distribution with parameters a and b.
+func Gumbel1Pdf(x, a, b float64) float64 {
+  return float64(C.gsl_ran_gumbel1_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Type-2 Gumbel distribution
+
+// Gumbel2 returns a random variate from the Type-2 Gumbel distribution
+// with parameters a and b.
+func (s RngState) Gumbel2(a, b float64) float64 {
+  return float64(C.gsl_ran_gumbel2(s.state, C.double(a), C.double(b)))
+}
+
+// Gumbel2Pdf computes the probability density p(x) at x for a Type-2
+// Gumbel distribution with parameters a and b.
+func Gumbel2Pdf(x, a, b float64) float64 {
+  return float64(C.gsl_ran_gumbel2_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Lognormal distribution
+
+// Lognormal returns a random variate from the lognormal distribution with
+// parameters zeta and sigma.
+func (s RngState) Lognormal(zeta, sigma float64) float64 {
+  return float64(C.gsl_ran_lognormal(s.state, C.double(zeta), C.double(sigma)))
+}
+
+// LognormalPdf computes the probability density p(x) at x for a lognormal
+// distribution with parameters zeta and sigma.
+func LognormalPdf(x, zeta, sigma float64) float64 {
+  return float64(C.gsl_ran_lognormal_pdf(C.double(x), C.double(zeta), C.double(sigma)))
+}
+
+// Flat (uniform) distribution
+
+// Flat returns a random variate from the flat (uniform) distribution on
+// the interval [a,b).
+func (s RngState) Flat(a, b float64) float64 {
+  return float64(C.gsl_ran_flat(s.state, C.double(a), C.double(b)))
+}
+
+// FlatPdf computes the probability density p(x) at x for a uniform
+// distribution on the interval [a,b).
+func FlatPdf(x, a, b float64) float64 {
+  return float64(C.gsl_ran_flat_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Dirichlet distribution
+
+// Dirichlet returns a random variate from the len(alpha)-dimensional
+// Dirichlet distribution with parameters alpha.
+func (s RngState) Dirichlet(alpha []float64) []float64 {
+  k := len(alpha)
+  theta := make([]float64, k)
+  if k == 0 {
+    return theta
+  }
+
+  C.gsl_ran_dirichlet(s.state, C.size_t(k),
+    (*C.double)(&alpha[0]), (*C.double)(&theta[0]))
+  return theta
+}
+
+// DirichletPdf computes the probability density p(theta) at theta for a
+// Dirichlet distribution with parameters alpha. alpha and theta must have
+// the same length.
+func DirichletPdf(alpha, theta []float64) float64 {
+  k := len(alpha)
+  if k == 0 {
+    return 0
+  }
+
+  return float64(C.gsl_ran_dirichlet_pdf(C.size_t(k),
+    (*C.double)(&alpha[0]), (*C.double)(&theta[0])))
+}
+
+// Discrete distributions
+
+// Bernoulli returns either 0 or 1, the result of a Bernoulli trial with
+// probability p.
+func (s RngState) Bernoulli(p float64) uint64 {
+  return uint64(C.gsl_ran_bernoulli(s.state, C.double(p)))
+}
+
+// BernoulliPdf computes the probability p(k) of obtaining k from a
+// Bernoulli distribution with probability parameter p.
+func BernoulliPdf(k uint64, p float64) float64 {
+  return float64(C.gsl_ran_bernoulli_pdf(C.uint(k), C.double(p)))
+}
+
+// Binomial returns a random integer from the binomial distribution, the
+// number of successes in n independent trials with probability p.
+func (s RngState) Binomial(p float64, n uint64) uint64 {
+  return uint64(C.gsl_ran_binomial(s.state, C.double(p), C.uint(n)))
+}
+
+// BinomialPdf computes the probability p(k) of obtaining k from a binomial
+// distribution with parameters p and n.
+func BinomialPdf(k uint64, p float64, n uint64) float64 {
+  return float64(C.gsl_ran_binomial_pdf(C.uint(k), C.double(p), C.uint(n)))
+}
+
+// NegativeBinomial returns a random integer from the negative binomial
+// distribution, the number of failures occurring before n successes in
+// independent trials with probability p of success.
+func (s RngState) NegativeBinomial(p, n float64) uint64 {
+  return uint64(C.gsl_ran_negative_binomial(s.state, C.double(p), C.double(n)))
+}
+
+// NegativeBinomialPdf computes the probability p(k) of obtaining k from a
+// negative binomial distribution with parameters p and n.
+func NegativeBinomialPdf(k uint64, p, n float64) float64 {
+  return float64(C.gsl_ran_negative_binomial_pdf(C.uint(k), C.double(p), C.double(n)))
+}
+
+// Poisson returns a random integer from the Poisson distribution with
+// mean mu.
+func (s RngState) Poisson(mu float64) uint64 {
+  return uint64(C.gsl_ran_poisson(s.state, C.double(mu)))
+}
+
+// PoissonPdf computes the probability p(k) of obtaining k from a Poisson
+// distribution with mean mu.
+func PoissonPdf(k uint64, mu float64) float64 {
+  return float64(C.gsl_ran_poisson_pdf(C.uint(k), C.double(mu)))
+}
+
+// Geometric returns a random integer from the geometric distribution, the
+// number of independent trials with probability p until the first success.
+func (s RngState) Geometric(p float64) uint64 {
+  return uint64(C.gsl_ran_geometric(s.state, C.double(p)))
+}
+
+// GeometricPdf computes the probability p(k) of obtaining k from a
+// geometric distribution with probability parameter p.
+func GeometricPdf(k uint64, p float64) float64 {
+  return float64(C.gsl_ran_geometric_pdf(C.uint(k), C.double(p)))
+}
+
+// Hypergeometric returns a random integer from the hypergeometric
+// distribution. The distribution describes the probability of obtaining
+// k objects of n1 from a population of n1+n2 where t objects are sampled
+// without replacement.
+func (s RngState) Hypergeometric(n1, n2, t uint64) uint64 {
+  return uint64(C.gsl_ran_hypergeometric(s.state, C.uint(n1), C.uint(n2), C.uint(t)))
+}
+
+// HypergeometricPdf computes the probability p(k) of obtaining k from a
+// hypergeometric distribution with parameters n1, n2 and t.
+func HypergeometricPdf(k, n1, n2, t uint64) float64 {
+  return float64(C.gsl_ran_hypergeometric_pdf(C.uint(k), C.uint(n1), C.uint(n2), C.uint(t)))
+}
+
+// Logarithmic returns a random integer from the logarithmic distribution
+// with probability parameter p.
+func (s RngState) Logarithmic(p float64) uint64 {
+  return uint64(C.gsl_ran_logarithmic(s.state, C.double(p)))
+}
+
+// LogarithmicPdf computes the probability p(k) of obtaining k from a
+// logarithmic distribution with probability parameter p.
+func LogarithmicPdf(k uint64, p float64) float64 {
+  return float64(C.gsl_ran_logarithmic_pdf(C.uint(k), C.double(p)))
+}
+
+// Multinomial returns a random sample from the len(p)-dimensional
+// multinomial distribution with N trials and category probabilities p,
+// which must sum to 1.
+func (s RngState) Multinomial(N uint64, p []float64) []uint64 {
+  k := len(p)
+  if k == 0 {
+    return []uint64{}
+  }
+
+  n := make([]C.uint, k)
+  C.gsl_ran_multinomial(s.state, C.size_t(k), C.uint(N),
+    (*C.double)(&p[0]), &n[0])
+
+  result := make([]uint64, k)
+  for i, v := range n {
+    result[i] = uint64(v)
+  }
+  return result
+}
+
+// MultinomialPdf computes the probability P(n_1, n_2, ..., n_K) of
+// sampling n from a multinomial distribution with category probabilities
+// p. p and n must have the same length.
+func MultinomialPdf(p []float64, n []uint64) float64 {
+  k := len(p)
+  if k == 0 {
+    return 0
+  }
+
+  cn := make([]C.uint, k)
+  for i, v := range n {
+    cn[i] = C.uint(v)
+  }
+  return float64(C.gsl_ran_multinomial_pdf(C.size_t(k), (*C.double)(&p[0]), &cn[0]))
+}
+
+// Spherical vector distributions
+
+// Dir2d returns a random direction vector (x,y) in two dimensions,
+// uniformly distributed on the unit circle.
+func (s RngState) Dir2d() (float64, float64) {
+  var x, y C.double
+  C.gsl_ran_dir_2d(s.state, &x, &y)
+  return float64(x), float64(y)
+}
+
+// Dir3d returns a random direction vector (x,y,z) in three dimensions,
+// uniformly distributed on the unit sphere.
+func (s RngState) Dir3d() (float64, float64, float64) {
+  var x, y, z C.double
+  C.gsl_ran_dir_3d(s.state, &x, &y, &z)
+  return float64(x), float64(y), float64(z)
+}
+
+// DirNd returns a random direction vector of length n, uniformly
+// distributed on the (n-1)-sphere.
+func (s RngState) DirNd(n int) []float64 {
+  x := make([]float64, n)
+  if n == 0 {
+    return x
+  }
+
+  C.gsl_ran_dir_nd(s.state, C.size_t(n), (*C.double)(&x[0]))
+  return x
+}
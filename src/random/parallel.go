@@ -0,0 +1,67 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// parallel provides substream generation for using RngState across
+// goroutines without sharing a single generator under a mutex
+package random
+
+// splitMix64 is the SplitMix64 mixing step used to turn a counter into a
+// well distributed 64-bit seed. It is the same construction used by
+// Rust's rand crate to decorrelate children derived from one parent seed.
+func splitMix64(x uint64) uint64 {
+  x += 0x9e3779b97f4a7c15
+  x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+  x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+  return x ^ (x >> 31)
+}
+
+// Split returns n new generators of the same type as s, suitable for
+// driving independent computations across goroutines instead of sharing
+// a single RngState under a mutex. GSL does not expose jump-ahead
+// polynomials for any of its generators, so true leapfrogging is not
+// available; substreams are instead decorrelated SeedSequence-style, by
+// drawing a seed from s for each child and mixing it with the child's
+// index through splitMix64. The children are independent RngState values
+// and do not share state with s or each other.
+func (s RngState) Split(n int) []RngState {
+  children := make([]RngState, n)
+  for i := 0; i < n; i++ {
+    child := Alloc(RngType{s.state._type})
+    child.Set(splitMix64(s.Get() ^ uint64(i)))
+    children[i] = child
+  }
+  return children
+}
+
+// jumpAheadChunk bounds the size of the scratch buffer JumpAhead draws
+// into, so fast-forwarding by a huge steps doesn't allocate a
+// correspondingly huge buffer.
+const jumpAheadChunk = 4096
+
+// JumpAhead advances the state of s as if steps values had already been
+// drawn from it, without returning those values. GSL provides no
+// jump-ahead polynomials (unlike, e.g., some generators described in the
+// GSL manual's discussion of parallel generation), so this is implemented
+// as a linear fast-forward: it costs O(steps) rather than the O(log
+// steps) a true jump-ahead would, but it leaves s in the same state that
+// steps calls to Get would have. The draws are made through GetBuffered
+// in chunks, the same amortized-cgo-crossing path GetSlice uses, since a
+// plain per-value Get loop pays a full cgo crossing per step and becomes
+// prohibitively slow for the large steps a jump-ahead is meant for.
+func (s RngState) JumpAhead(steps uint64) {
+  size := uint64(jumpAheadChunk)
+  if steps < size {
+    size = steps
+  }
+  buf := make([]uint64, size)
+
+  for steps > 0 {
+    n := uint64(len(buf))
+    if steps < n {
+      n = steps
+    }
+    s.GetBuffered(buf[:n])
+    steps -= n
+  }
+}
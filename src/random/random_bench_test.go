@@ -0,0 +1,71 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package random
+
+import "testing"
+
+// benchBatchSize is the number of values drawn per b.N iteration in the
+// benchmarks below, large enough that the per-call loops' benchBatchSize
+// cgo crossings dominate their timing while the buffered path's single
+// crossing is amortized over the whole batch.
+const benchBatchSize = 10000
+
+func benchRng() RngState {
+  r := Alloc(Mt19937)
+  r.Set(DefaultSeed())
+  return r
+}
+
+// BenchmarkGetLoop fills a buffer by calling Get once per element,
+// crossing the cgo boundary benchBatchSize times per iteration.
+func BenchmarkGetLoop(b *testing.B) {
+  r := benchRng()
+  buf := make([]uint64, benchBatchSize)
+
+  b.ResetTimer()
+  for i := 0; i < b.N; i++ {
+    for j := range buf {
+      buf[j] = r.Get()
+    }
+  }
+}
+
+// BenchmarkGetBuffered fills the same size buffer via GetBuffered, which
+// crosses the cgo boundary once per iteration.
+func BenchmarkGetBuffered(b *testing.B) {
+  r := benchRng()
+  buf := make([]uint64, benchBatchSize)
+
+  b.ResetTimer()
+  for i := 0; i < b.N; i++ {
+    r.GetBuffered(buf)
+  }
+}
+
+// BenchmarkUniformLoop fills a buffer by calling Uniform once per
+// element, crossing the cgo boundary benchBatchSize times per iteration.
+func BenchmarkUniformLoop(b *testing.B) {
+  r := benchRng()
+  buf := make([]float64, benchBatchSize)
+
+  b.ResetTimer()
+  for i := 0; i < b.N; i++ {
+    for j := range buf {
+      buf[j] = r.Uniform()
+    }
+  }
+}
+
+// BenchmarkUniformBuffered fills the same size buffer via
+// UniformBuffered, which crosses the cgo boundary once per iteration.
+func BenchmarkUniformBuffered(b *testing.B) {
+  r := benchRng()
+  buf := make([]float64, benchBatchSize)
+
+  b.ResetTimer()
+  for i := 0; i < b.N; i++ {
+    r.UniformBuffered(buf)
+  }
+}
@@ -0,0 +1,69 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// source makes RngState satisfy the math/rand.Source64 interface so a
+// GSL generator can drive the standard library's distribution routines
+package random
+
+import "math/bits"
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64,
+// satisfying math/rand.Source.
+func (s RngState) Int63() int64 {
+  return int64(s.Uint64() >> 1)
+}
+
+// Seed uses seed to initialize the generator to a deterministic state,
+// satisfying math/rand.Source. It is a thin wrapper around Set.
+func (s RngState) Seed(seed int64) {
+  s.Set(uint64(seed))
+}
+
+// uint64Bits draws one value from s, offsets it into a zero-based range
+// by subtracting Min, and reports how many low bits of the result are
+// actually usable -- the bit width of Max()-Min(). Generators whose range
+// isn't a clean power of two (e.g. Minstd's [1,2147483646] or Ranf's
+// decimal range) still only yield that many usable bits per draw; the
+// rest are masked off rather than silently included.
+func (s RngState) uint64Bits() (value uint64, width uint) {
+  span := s.Max() - s.Min()
+  width = uint(bits.Len64(span))
+  if width == 0 {
+    width = 1
+  }
+
+  value = s.Get() - s.Min()
+  if width < 64 {
+    value &= (uint64(1) << width) - 1
+  }
+  return value, width
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64, satisfying
+// math/rand.Source64. Generators whose range [Min(),Max()] already spans
+// the full 64-bit space are sampled directly with Get. All other
+// generators -- which is most of them, since Mt19937, Taus2, Ranlxd1 and
+// friends top out at 2^32-1, and the Minstd/Ranf/Randu-style compatibility
+// generators have odd, non-zero-based ranges -- are sampled repeatedly via
+// uint64Bits, which masks each draw down to Max()-Min()'s actual bit
+// width and offsets it by Min, and the resulting chunks are packed
+// together until 64 bits have been filled.
+func (s RngState) Uint64() uint64 {
+  if s.Min() == 0 && s.Max() == ^uint64(0) {
+    return s.Get()
+  }
+
+  var result uint64
+  var filled uint
+  for filled < 64 {
+    value, width := s.uint64Bits()
+    if filled+width > 64 {
+      width = 64 - filled
+      value &= (uint64(1) << width) - 1
+    }
+    result |= value << filled
+    filled += width
+  }
+  return result
+}